@@ -0,0 +1,88 @@
+// Package control implements path-tracking steering controllers for CarModel.
+package control
+
+import (
+	"math"
+
+	"github.com/lnsp/pdi-carsim/geometry"
+)
+
+// CarState is the subset of a car's dynamic state a CarController needs to compute a steering command.
+type CarState struct {
+	RearAxle  geometry.Vector // world position of the rear axle
+	Heading   float64         // heading angle, in the same convention as CarModel.Heading
+	Velocity  float64         // forward velocity
+	Wheelbase float64         // distance between the front and rear axle
+}
+
+// Forward returns the unit vector pointing in the car's forward direction.
+func (s CarState) Forward() geometry.Vector {
+	return geometry.X.RotateAround(geometry.Null, s.Heading)
+}
+
+// FrontAxle returns the world position of the front axle.
+func (s CarState) FrontAxle() geometry.Vector {
+	return s.RearAxle.Add(s.Forward().Scale(s.Wheelbase))
+}
+
+// CarController computes a steering angle that tracks a target path. spline is the smoothed
+// curve path was resampled from, for controllers that need its tangent/curvature rather than the
+// coarser per-segment geometry of path itself.
+type CarController interface {
+	Steer(state CarState, path geometry.Polygon, spline *geometry.Spline) float64
+}
+
+// PurePursuitController steers toward a point at a lookahead distance ahead of the rear axle
+// along the target path, advancing in arc length so the lookahead point stays well defined even
+// when the path self-intersects.
+type PurePursuitController struct {
+	LookaheadGain float64 // k, scales the lookahead distance with speed
+	LookaheadBase float64 // L0, minimum lookahead distance
+	MaxSteer      float64
+}
+
+func (c *PurePursuitController) Steer(state CarState, path geometry.Polygon, _ *geometry.Spline) float64 {
+	ld := c.LookaheadGain*state.Velocity + c.LookaheadBase
+
+	segIdx, t, _ := path.NearestPoint(state.RearAxle)
+	s0 := path.ArcLengthAt(segIdx, t)
+	_, _, target := path.PointAtArcLength(s0 + ld)
+
+	diff := target.Add(state.RearAxle.Scale(-1))
+	// Heading grows as Forward turns toward the negative Det side (RotateAround is a reflection),
+	// so the bearing is measured from diff back to Forward to match the sign of the steering angle.
+	alpha := diff.AngleBetween(state.Forward())
+	delta := math.Atan2(2*state.Wheelbase*math.Sin(alpha), ld)
+
+	return clamp(delta, -c.MaxSteer, c.MaxSteer)
+}
+
+// StanleyController steers using the cross-track error of the front axle against the nearest
+// path segment, plus the heading error against the desired heading at that point on spline.
+type StanleyController struct {
+	Gain      float64 // k
+	Softening float64 // epsilon, avoids a singularity at v == 0
+	MaxSteer  float64
+}
+
+func (c *StanleyController) Steer(state CarState, path geometry.Polygon, spline *geometry.Spline) float64 {
+	front := state.FrontAxle()
+
+	n := len(path)
+	segIdx, t, nearest := path.NearestPoint(front)
+	a, b := path[segIdx], path[(segIdx+1)%n]
+	edgeDir := b.Add(a.Scale(-1)).Norm()
+
+	crossTrack := edgeDir.Det(front.Add(nearest.Scale(-1)))
+	tangent := spline.Tangent((float64(segIdx) + t) / float64(n-1))
+	// Measured from tangent back to Forward, for the same reason as alpha in PurePursuitController.
+	psi := tangent.AngleBetween(state.Forward())
+
+	delta := psi + math.Atan2(c.Gain*crossTrack, state.Velocity+c.Softening)
+
+	return clamp(delta, -c.MaxSteer, c.MaxSteer)
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Min(hi, math.Max(lo, v))
+}
@@ -0,0 +1,86 @@
+package control
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lnsp/pdi-carsim/geometry"
+)
+
+// straightPath builds a straight-line path along the X axis together with the spline it was
+// resampled from, mirroring how sim.GenerateRandomPath pairs the two.
+func straightPath() (geometry.Polygon, *geometry.Spline) {
+	points := []geometry.Vector{{X: 0, Y: 0}, {X: 50, Y: 0}, {X: 100, Y: 0}, {X: 150, Y: 0}}
+	spline := geometry.NewCatmullRomSpline(points, 0)
+	return spline.Samples(16), spline
+}
+
+func TestPurePursuitSteersStraightWhenOnPath(t *testing.T) {
+	path, spline := straightPath()
+	c := &PurePursuitController{LookaheadGain: 0.5, LookaheadBase: 10, MaxSteer: math.Pi / 4}
+	state := CarState{RearAxle: geometry.Vector{X: 0, Y: 0}, Heading: 0, Velocity: 10, Wheelbase: 2.5}
+
+	if got := c.Steer(state, path, spline); math.Abs(got) > 1e-6 {
+		t.Errorf("Steer() = %v, want ~0 when already centered on a straight path", got)
+	}
+}
+
+func TestPurePursuitSteersOppositelyForOppositeOffsets(t *testing.T) {
+	path, spline := straightPath()
+	c := &PurePursuitController{LookaheadGain: 0.5, LookaheadBase: 10, MaxSteer: math.Pi / 4}
+
+	above := CarState{RearAxle: geometry.Vector{X: 0, Y: 5}, Heading: 0, Velocity: 10, Wheelbase: 2.5}
+	below := CarState{RearAxle: geometry.Vector{X: 0, Y: -5}, Heading: 0, Velocity: 10, Wheelbase: 2.5}
+
+	deltaAbove := c.Steer(above, path, spline)
+	deltaBelow := c.Steer(below, path, spline)
+
+	if deltaAbove == 0 || deltaBelow == 0 {
+		t.Fatalf("Steer() = %v, %v, want nonzero corrections when off the path", deltaAbove, deltaBelow)
+	}
+	if math.Signbit(deltaAbove) == math.Signbit(deltaBelow) {
+		t.Errorf("Steer() = %v, %v, want opposite signs for offsets on either side of the path", deltaAbove, deltaBelow)
+	}
+	if math.Abs(deltaAbove+deltaBelow) > 1e-9 {
+		t.Errorf("Steer() = %v, %v, want equal-magnitude corrections for a symmetric offset", deltaAbove, deltaBelow)
+	}
+}
+
+func TestStanleySteersStraightWhenOnPath(t *testing.T) {
+	path, spline := straightPath()
+	c := &StanleyController{Gain: 1, Softening: 1, MaxSteer: math.Pi / 4}
+	state := CarState{RearAxle: geometry.Vector{X: 0, Y: 0}, Heading: 0, Velocity: 10, Wheelbase: 2.5}
+
+	if got := c.Steer(state, path, spline); math.Abs(got) > 1e-6 {
+		t.Errorf("Steer() = %v, want ~0 when already centered on a straight path", got)
+	}
+}
+
+func TestStanleySteersOppositelyForOppositeOffsets(t *testing.T) {
+	path, spline := straightPath()
+	c := &StanleyController{Gain: 1, Softening: 1, MaxSteer: math.Pi / 4}
+
+	above := CarState{RearAxle: geometry.Vector{X: 0, Y: 5}, Heading: 0, Velocity: 10, Wheelbase: 2.5}
+	below := CarState{RearAxle: geometry.Vector{X: 0, Y: -5}, Heading: 0, Velocity: 10, Wheelbase: 2.5}
+
+	deltaAbove := c.Steer(above, path, spline)
+	deltaBelow := c.Steer(below, path, spline)
+
+	if deltaAbove == 0 || deltaBelow == 0 {
+		t.Fatalf("Steer() = %v, %v, want nonzero corrections when off the path", deltaAbove, deltaBelow)
+	}
+	if math.Signbit(deltaAbove) == math.Signbit(deltaBelow) {
+		t.Errorf("Steer() = %v, %v, want opposite signs for offsets on either side of the path", deltaAbove, deltaBelow)
+	}
+	if math.Abs(deltaAbove+deltaBelow) > 1e-9 {
+		t.Errorf("Steer() = %v, %v, want equal-magnitude corrections for a symmetric offset", deltaAbove, deltaBelow)
+	}
+}
+
+func TestCarStateFrontAxle(t *testing.T) {
+	state := CarState{RearAxle: geometry.Vector{X: 0, Y: 0}, Heading: 0, Wheelbase: 2.5}
+	want := geometry.Vector{X: 2.5, Y: 0}
+	if got := state.FrontAxle(); math.Abs(got.X-want.X) > 1e-9 || math.Abs(got.Y-want.Y) > 1e-9 {
+		t.Errorf("FrontAxle() = %v, want %v", got, want)
+	}
+}
@@ -66,6 +66,30 @@ func (v Vector) RotateAround(a Vector, angle float64) Vector {
 	}
 }
 
+// Seg2 is a 2D line segment between two points.
+type Seg2 struct {
+	A, B Vector
+}
+
+// Intersect finds the intersection of two line segments using the standard parametric form:
+// points on the segments are p + t*r and q + u*s, with r = s.B-s.A and s = other.B-other.A; the
+// segments cross where t and u both lie in [0, 1].
+func (s Seg2) Intersect(other Seg2) (Vector, bool) {
+	r := s.B.Add(s.A.Scale(-1))
+	u := other.B.Add(other.A.Scale(-1))
+	rxu := r.Det(u)
+	if rxu == 0 {
+		return Vector{}, false
+	}
+	diff := other.A.Add(s.A.Scale(-1))
+	t := diff.Det(u) / rxu
+	v := diff.Det(r) / rxu
+	if t < 0 || t > 1 || v < 0 || v > 1 {
+		return Vector{}, false
+	}
+	return s.A.Add(r.Scale(t)), true
+}
+
 type Polygon []Vector
 
 func NewPolygon(components ...Vector) Polygon {
@@ -111,6 +135,139 @@ func (p Polygon) Points() []sdl.Point {
 	return points
 }
 
+// NearestPoint returns the point on the polygon's boundary (treated as a closed loop) that is
+// nearest to v, together with the index of the segment it lies on and the segment-local
+// interpolation parameter t in [0, 1].
+func (p Polygon) NearestPoint(v Vector) (segmentIdx int, t float64, point Vector) {
+	n := len(p)
+	if n == 0 {
+		return 0, 0, Null
+	}
+	bestDist := math.Inf(1)
+	for i := 0; i < n; i++ {
+		a, b := p[i], p[(i+1)%n]
+		segment := b.Add(a.Scale(-1))
+		segLen2 := segment.Dot(segment)
+		u := 0.0
+		if segLen2 > 0 {
+			u = math.Min(1.0, math.Max(0.0, v.Add(a.Scale(-1)).Dot(segment)/segLen2))
+		}
+		candidate := a.Add(segment.Scale(u))
+		if dist := candidate.Add(v.Scale(-1)).Len(); dist < bestDist {
+			bestDist, segmentIdx, t, point = dist, i, u, candidate
+		}
+	}
+	return segmentIdx, t, point
+}
+
+// arcLengths returns the cumulative boundary length at each vertex (length n+1, closed loop)
+// together with the total boundary length.
+func (p Polygon) arcLengths() ([]float64, float64) {
+	n := len(p)
+	lengths := make([]float64, n+1)
+	total := 0.0
+	for i := 0; i < n; i++ {
+		total += p[(i+1)%n].Add(p[i].Scale(-1)).Len()
+		lengths[i+1] = total
+	}
+	return lengths, total
+}
+
+// TotalLength returns the length of the polygon's boundary, treated as a closed loop.
+func (p Polygon) TotalLength() float64 {
+	_, total := p.arcLengths()
+	return total
+}
+
+// ArcLengthAt returns the cumulative boundary length from vertex 0 up to segment segmentIdx at
+// parameter t, as returned by NearestPoint.
+func (p Polygon) ArcLengthAt(segmentIdx int, t float64) float64 {
+	lengths, _ := p.arcLengths()
+	if segmentIdx+1 >= len(lengths) {
+		return lengths[len(lengths)-1]
+	}
+	return lengths[segmentIdx] + (lengths[segmentIdx+1]-lengths[segmentIdx])*t
+}
+
+// PointAtArcLength returns the point at boundary arc length s, measured from vertex 0 and
+// wrapping around the closed loop. Because it is parameterized by arc length rather than by
+// vertex index, progress stays monotonic even along self-intersecting paths.
+func (p Polygon) PointAtArcLength(s float64) (segmentIdx int, t float64, point Vector) {
+	n := len(p)
+	if n == 0 {
+		return 0, 0, Null
+	}
+	lengths, total := p.arcLengths()
+	if total == 0 {
+		return 0, 0, p[0]
+	}
+	s = math.Mod(s, total)
+	if s < 0 {
+		s += total
+	}
+	for i := 0; i < n; i++ {
+		if s <= lengths[i+1] || i == n-1 {
+			segLen := lengths[i+1] - lengths[i]
+			u := 0.0
+			if segLen > 0 {
+				u = (s - lengths[i]) / segLen
+			}
+			a, b := p[i], p[(i+1)%n]
+			return i, u, a.Add(b.Add(a.Scale(-1)).Scale(u))
+		}
+	}
+	return n - 1, 1, p[0]
+}
+
+// edgeNormals returns the unit-length normal of each edge of the polygon, treated as a closed
+// loop: the edge vector (x, y) turned to (-y, x). Whether it points out of or into the polygon
+// depends on the vertex order.
+func (p Polygon) edgeNormals() []Vector {
+	n := len(p)
+	normals := make([]Vector, n)
+	for i := 0; i < n; i++ {
+		edge := p[(i+1)%n].Add(p[i].Scale(-1))
+		normals[i] = Vector{X: -edge.Y, Y: edge.X}.Norm()
+	}
+	return normals
+}
+
+// projectOnto projects every vertex of the polygon onto axis and returns the resulting interval.
+func (p Polygon) projectOnto(axis Vector) (min, max float64) {
+	min, max = math.Inf(1), math.Inf(-1)
+	for _, v := range p {
+		d := v.Dot(axis)
+		if d < min {
+			min = d
+		}
+		if d > max {
+			max = d
+		}
+	}
+	return min, max
+}
+
+// SAT tests two convex polygons for overlap using the Separating Axis Theorem. If they collide,
+// it returns the smallest projection overlap found over the edge normals of both polygons and
+// the normal it was found on. The sign of axis follows the edge it belongs to; it is not
+// oriented from other toward p.
+func (p Polygon) SAT(other Polygon) (overlap float64, axis Vector, colliding bool) {
+	minOverlap := math.Inf(1)
+	var minAxis Vector
+	for _, a := range append(p.edgeNormals(), other.edgeNormals()...) {
+		pMin, pMax := p.projectOnto(a)
+		oMin, oMax := other.projectOnto(a)
+		o := math.Min(pMax, oMax) - math.Max(pMin, oMin)
+		if o <= 0 {
+			return 0, Vector{}, false
+		}
+		if o < minOverlap {
+			minOverlap, minAxis = o, a
+		}
+	}
+	return minOverlap, minAxis, true
+}
+
 func (p Polygon) Interpolate(v float64) Vector {
 	pointCount := len(p)
 	connections := make([]float64, pointCount+1)
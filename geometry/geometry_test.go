@@ -0,0 +1,79 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSeg2IntersectCrossing(t *testing.T) {
+	a := Seg2{A: Vector{X: 0, Y: 0}, B: Vector{X: 2, Y: 2}}
+	b := Seg2{A: Vector{X: 0, Y: 2}, B: Vector{X: 2, Y: 0}}
+
+	got, ok := a.Intersect(b)
+	if !ok {
+		t.Fatalf("Intersect() = _, false; want an intersection at (1, 1)")
+	}
+	want := Vector{X: 1, Y: 1}
+	if math.Abs(got.X-want.X) > 1e-9 || math.Abs(got.Y-want.Y) > 1e-9 {
+		t.Errorf("Intersect() = %v, want %v", got, want)
+	}
+}
+
+func TestSeg2IntersectParallel(t *testing.T) {
+	a := Seg2{A: Vector{X: 0, Y: 0}, B: Vector{X: 1, Y: 0}}
+	b := Seg2{A: Vector{X: 0, Y: 1}, B: Vector{X: 1, Y: 1}}
+
+	if _, ok := a.Intersect(b); ok {
+		t.Errorf("Intersect() on parallel segments = true, want false")
+	}
+}
+
+func TestSeg2IntersectOutsideSegmentBounds(t *testing.T) {
+	a := Seg2{A: Vector{X: 0, Y: 0}, B: Vector{X: 1, Y: 1}}
+	b := Seg2{A: Vector{X: 3, Y: 0}, B: Vector{X: 3, Y: 1}}
+
+	if _, ok := a.Intersect(b); ok {
+		t.Errorf("Intersect() on non-overlapping lines = true, want false")
+	}
+}
+
+func TestPolygonSATOverlappingSquares(t *testing.T) {
+	p := NewPolygon(Vector{X: 0, Y: 0}, Vector{X: 2, Y: 0}, Vector{X: 2, Y: 2}, Vector{X: 0, Y: 2})
+	q := NewPolygon(Vector{X: 1, Y: 0}, Vector{X: 3, Y: 0}, Vector{X: 3, Y: 2}, Vector{X: 1, Y: 2})
+
+	overlap, axis, colliding := p.SAT(q)
+	if !colliding {
+		t.Fatalf("SAT() colliding = false, want true")
+	}
+	if math.Abs(overlap-1) > 1e-9 {
+		t.Errorf("SAT() overlap = %v, want 1", overlap)
+	}
+	if math.Abs(math.Abs(axis.X)-1) > 1e-9 || math.Abs(axis.Y) > 1e-9 {
+		t.Errorf("SAT() axis = %v, want a horizontal unit vector", axis)
+	}
+}
+
+func TestPolygonSATSeparated(t *testing.T) {
+	p := NewPolygon(Vector{X: 0, Y: 0}, Vector{X: 1, Y: 0}, Vector{X: 1, Y: 1}, Vector{X: 0, Y: 1})
+	q := NewPolygon(Vector{X: 5, Y: 0}, Vector{X: 6, Y: 0}, Vector{X: 6, Y: 1}, Vector{X: 5, Y: 1})
+
+	if _, _, colliding := p.SAT(q); colliding {
+		t.Errorf("SAT() colliding = true, want false")
+	}
+}
+
+func TestPolygonNearestPoint(t *testing.T) {
+	p := NewPolygon(Vector{X: 0, Y: 0}, Vector{X: 4, Y: 0}, Vector{X: 4, Y: 4}, Vector{X: 0, Y: 4})
+
+	segIdx, u, point := p.NearestPoint(Vector{X: 2, Y: -1})
+	if segIdx != 0 {
+		t.Errorf("NearestPoint() segIdx = %d, want 0", segIdx)
+	}
+	if math.Abs(u-0.5) > 1e-9 {
+		t.Errorf("NearestPoint() t = %v, want 0.5", u)
+	}
+	want := Vector{X: 2, Y: 0}
+	if math.Abs(point.X-want.X) > 1e-9 || math.Abs(point.Y-want.Y) > 1e-9 {
+		t.Errorf("NearestPoint() point = %v, want %v", point, want)
+	}
+}
@@ -0,0 +1,227 @@
+package geometry
+
+import (
+	"math"
+	"sort"
+)
+
+// SplineKind selects the basis a Spline uses to blend between its control points.
+type SplineKind int
+
+const (
+	// CatmullRom evaluates each segment directly from the Hermite-form Catmull-Rom polynomial.
+	CatmullRom SplineKind = iota
+	// CubicBezier evaluates each segment as a cubic Bezier curve whose control points are
+	// derived from the same Catmull-Rom tangents, so it traces the same C1-continuous curve.
+	CubicBezier
+)
+
+// splineSamples is the number of arc-length samples taken per segment when building a Spline's
+// lookup table.
+const splineSamples = 32
+
+// Spline is a piecewise C1-continuous curve through a sequence of control points, reparameterized
+// by arc length so that Interpolate(u) advances along the curve at a constant rate as u sweeps
+// [0, 1].
+type Spline struct {
+	points  []Vector
+	kind    SplineKind
+	tension float64
+
+	table []splineSample
+}
+
+// splineSample is one entry of a Spline's precomputed arc-length lookup table.
+type splineSample struct {
+	length  float64 // cumulative arc length from the start of the spline up to (segment, t)
+	segment int
+	t       float64
+}
+
+// NewCatmullRomSpline builds a Spline that interpolates points using the Catmull-Rom basis with
+// the given tension tau (0 reproduces the standard uniform Catmull-Rom curve; tau in (0, 1)
+// progressively straightens the curve near each control point).
+func NewCatmullRomSpline(points []Vector, tension float64) *Spline {
+	return newSpline(points, CatmullRom, tension)
+}
+
+// NewBezierSpline builds a Spline that interpolates points using cubic Bezier segments whose
+// control points are derived from the Catmull-Rom tangents at tension tau, so it traces the same
+// curve as the equivalent NewCatmullRomSpline.
+func NewBezierSpline(points []Vector, tension float64) *Spline {
+	return newSpline(points, CubicBezier, tension)
+}
+
+func newSpline(points []Vector, kind SplineKind, tension float64) *Spline {
+	s := &Spline{points: points, kind: kind, tension: tension}
+	s.buildTable()
+	return s
+}
+
+func (s *Spline) segmentCount() int {
+	return len(s.points) - 1
+}
+
+// neighbors returns the four points used to evaluate segment i, between points[i] and
+// points[i+1], duplicating the outer endpoint for the first and last segments.
+func (s *Spline) neighbors(i int) (p0, p1, p2, p3 Vector) {
+	n := len(s.points)
+	p1, p2 = s.points[i], s.points[i+1]
+	if i == 0 {
+		p0 = s.points[0]
+	} else {
+		p0 = s.points[i-1]
+	}
+	if i+2 >= n {
+		p3 = s.points[n-1]
+	} else {
+		p3 = s.points[i+2]
+	}
+	return p0, p1, p2, p3
+}
+
+// eval returns the position, velocity (d/dt) and acceleration (d2/dt2) of segment i at local
+// parameter t in [0, 1].
+func (s *Spline) eval(segment int, t float64) (pos, vel, acc Vector) {
+	p0, p1, p2, p3 := s.neighbors(segment)
+	m1 := p2.Add(p0.Scale(-1)).Scale((1 - s.tension) / 2)
+	m2 := p3.Add(p1.Scale(-1)).Scale((1 - s.tension) / 2)
+
+	if s.kind == CubicBezier {
+		b0, b1, b2, b3 := hermiteToBezier(p1, p2, m1, m2)
+		return cubicBezierEval(b0, b1, b2, b3, t)
+	}
+	return hermiteEval(p1, p2, m1, m2, t)
+}
+
+// hermiteEval evaluates the cubic Hermite segment through p1 and p2 with tangents m1 and m2. At
+// m1 = (p2-p0)/2 and m2 = (p3-p1)/2 (tension tau = 0) this reduces to the expanded Catmull-Rom
+// polynomial P(t) = 0.5*[2*p1 + (p2-p0)*t + (2*p0-5*p1+4*p2-p3)*t^2 + (-p0+3*p1-3*p2+p3)*t^3].
+func hermiteEval(p1, p2, m1, m2 Vector, t float64) (pos, vel, acc Vector) {
+	h00, h10, h01, h11 := 2*t*t*t-3*t*t+1, t*t*t-2*t*t+t, -2*t*t*t+3*t*t, t*t*t-t*t
+	dh00, dh10, dh01, dh11 := 6*t*t-6*t, 3*t*t-4*t+1, 6*t-6*t*t, 3*t*t-2*t
+	ddh00, ddh10, ddh01, ddh11 := 12*t-6, 6*t-4, 6-12*t, 6*t-2
+
+	pos = p1.Scale(h00).Add(m1.Scale(h10)).Add(p2.Scale(h01)).Add(m2.Scale(h11))
+	vel = p1.Scale(dh00).Add(m1.Scale(dh10)).Add(p2.Scale(dh01)).Add(m2.Scale(dh11))
+	acc = p1.Scale(ddh00).Add(m1.Scale(ddh10)).Add(p2.Scale(ddh01)).Add(m2.Scale(ddh11))
+	return pos, vel, acc
+}
+
+// hermiteToBezier converts a Hermite segment (endpoints p1, p2 with tangents m1, m2) into the
+// equivalent cubic Bezier control points.
+func hermiteToBezier(p1, p2, m1, m2 Vector) (b0, b1, b2, b3 Vector) {
+	return p1, p1.Add(m1.Scale(1.0 / 3)), p2.Add(m2.Scale(-1.0 / 3)), p2
+}
+
+func cubicBezierEval(b0, b1, b2, b3 Vector, t float64) (pos, vel, acc Vector) {
+	u := 1 - t
+	pos = b0.Scale(u * u * u).Add(b1.Scale(3 * u * u * t)).Add(b2.Scale(3 * u * t * t)).Add(b3.Scale(t * t * t))
+	vel = b1.Add(b0.Scale(-1)).Scale(3 * u * u).
+		Add(b2.Add(b1.Scale(-1)).Scale(6 * u * t)).
+		Add(b3.Add(b2.Scale(-1)).Scale(3 * t * t))
+	acc = b2.Add(b1.Scale(-2)).Add(b0).Scale(6 * u).
+		Add(b3.Add(b2.Scale(-2)).Add(b1).Scale(6 * t))
+	return pos, vel, acc
+}
+
+// buildTable samples every segment at a fixed resolution and records the cumulative arc length at
+// each sample, so Interpolate can binary search it instead of walking the segments.
+func (s *Spline) buildTable() {
+	segments := s.segmentCount()
+	if segments <= 0 {
+		s.table = []splineSample{{0, 0, 0}}
+		return
+	}
+
+	table := make([]splineSample, 0, segments*splineSamples+1)
+	length := 0.0
+	prev, _, _ := s.eval(0, 0)
+	table = append(table, splineSample{0, 0, 0})
+	for segment := 0; segment < segments; segment++ {
+		for i := 1; i <= splineSamples; i++ {
+			t := float64(i) / float64(splineSamples)
+			pos, _, _ := s.eval(segment, t)
+			length += pos.Add(prev.Scale(-1)).Len()
+			table = append(table, splineSample{length, segment, t})
+			prev = pos
+		}
+	}
+	s.table = table
+}
+
+// TotalLength returns the arc length of the whole spline.
+func (s *Spline) TotalLength() float64 {
+	return s.table[len(s.table)-1].length
+}
+
+// locate maps an arc-length fraction u in [0, 1] to the (segment, t) pair at that point along the
+// curve, binary searching the precomputed length table.
+func (s *Spline) locate(u float64) (segment int, t float64) {
+	target := math.Min(1.0, math.Max(0.0, u)) * s.TotalLength()
+	idx := sort.Search(len(s.table), func(i int) bool { return s.table[i].length >= target })
+
+	if idx <= 0 {
+		return s.table[0].segment, s.table[0].t
+	}
+	if idx >= len(s.table) {
+		last := s.table[len(s.table)-1]
+		return last.segment, last.t
+	}
+
+	lo, hi := s.table[idx-1], s.table[idx]
+	if hi.length == lo.length {
+		return hi.segment, hi.t
+	}
+	loT := lo.t
+	if hi.segment != lo.segment {
+		// lo ends the previous segment, which is the same point as t = 0 of hi's segment.
+		loT = 0
+	}
+	frac := (target - lo.length) / (hi.length - lo.length)
+	return hi.segment, loT + (hi.t-loT)*frac
+}
+
+// Interpolate returns the point at arc-length fraction u in [0, 1] along the spline.
+func (s *Spline) Interpolate(u float64) Vector {
+	segment, t := s.locate(u)
+	pos, _, _ := s.eval(segment, t)
+	return pos
+}
+
+// Tangent returns the unit tangent direction at arc-length fraction u, as needed by the Stanley
+// controller to compute its desired heading.
+func (s *Spline) Tangent(u float64) Vector {
+	segment, t := s.locate(u)
+	_, vel, _ := s.eval(segment, t)
+	return vel.Norm()
+}
+
+// Curvature returns the signed curvature at arc-length fraction u, so a controller can slow down
+// ahead of tight corners.
+func (s *Spline) Curvature(u float64) float64 {
+	segment, t := s.locate(u)
+	_, vel, acc := s.eval(segment, t)
+	denom := math.Pow(vel.Dot(vel), 1.5)
+	if denom == 0 {
+		return 0
+	}
+	return vel.Det(acc) / denom
+}
+
+// Samples resamples the spline into a Polygon of n points, evenly spaced by arc length. It is
+// used to turn a Spline back into the Polygon representation the rest of the package works with.
+func (s *Spline) Samples(n int) Polygon {
+	if n <= 0 {
+		return nil
+	}
+	points := make([]Vector, n)
+	for i := range points {
+		u := 0.0
+		if n > 1 {
+			u = float64(i) / float64(n-1)
+		}
+		points[i] = s.Interpolate(u)
+	}
+	return NewPolygon(points...)
+}
@@ -0,0 +1,79 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSplineInterpolateEndpoints(t *testing.T) {
+	points := []Vector{{X: 0, Y: 0}, {X: 10, Y: 5}, {X: 20, Y: -5}, {X: 30, Y: 0}}
+	s := NewCatmullRomSpline(points, 0)
+
+	if got := s.Interpolate(0); math.Abs(got.X) > 1e-9 || math.Abs(got.Y) > 1e-9 {
+		t.Errorf("Interpolate(0) = %v, want %v", got, points[0])
+	}
+	want := points[len(points)-1]
+	if got := s.Interpolate(1); math.Abs(got.X-want.X) > 1e-9 || math.Abs(got.Y-want.Y) > 1e-9 {
+		t.Errorf("Interpolate(1) = %v, want %v", got, want)
+	}
+}
+
+func TestSplineStraightLineIsFlat(t *testing.T) {
+	points := []Vector{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 20, Y: 0}, {X: 30, Y: 0}}
+	s := NewCatmullRomSpline(points, 0)
+
+	if got := s.TotalLength(); math.Abs(got-30) > 1e-6 {
+		t.Errorf("TotalLength() = %v, want 30", got)
+	}
+	for _, u := range []float64{0, 0.25, 0.5, 0.75, 1} {
+		if got := s.Curvature(u); math.Abs(got) > 1e-6 {
+			t.Errorf("Curvature(%v) = %v, want ~0 on a collinear path", u, got)
+		}
+		tangent := s.Tangent(u)
+		if math.Abs(tangent.Len()-1) > 1e-6 {
+			t.Errorf("Tangent(%v).Len() = %v, want 1", u, tangent.Len())
+		}
+		if math.Abs(tangent.Y) > 1e-6 {
+			t.Errorf("Tangent(%v) = %v, want a horizontal direction", u, tangent)
+		}
+	}
+}
+
+func TestBezierSplineMatchesCatmullRom(t *testing.T) {
+	points := []Vector{{X: 0, Y: 0}, {X: 10, Y: 8}, {X: 25, Y: -6}, {X: 40, Y: 2}, {X: 55, Y: 0}}
+	catmullRom := NewCatmullRomSpline(points, 0)
+	bezier := NewBezierSpline(points, 0)
+
+	for _, u := range []float64{0, 0.2, 0.5, 0.8, 1} {
+		a, b := catmullRom.Interpolate(u), bezier.Interpolate(u)
+		if math.Abs(a.X-b.X) > 1e-6 || math.Abs(a.Y-b.Y) > 1e-6 {
+			t.Errorf("Interpolate(%v): CatmullRom = %v, Bezier = %v, want equal", u, a, b)
+		}
+	}
+}
+
+func TestSplineSamplesIsArcLengthUniform(t *testing.T) {
+	points := []Vector{{X: 0, Y: 0}, {X: 10, Y: 5}, {X: 20, Y: -5}, {X: 30, Y: 0}}
+	s := NewCatmullRomSpline(points, 0)
+
+	n := 9
+	samples := s.Samples(n)
+	if len(samples) != n {
+		t.Fatalf("Samples(%d) returned %d points", n, len(samples))
+	}
+	first, last := s.Interpolate(0), s.Interpolate(1)
+	if math.Abs(samples[0].X-first.X) > 1e-9 || math.Abs(samples[0].Y-first.Y) > 1e-9 {
+		t.Errorf("Samples()[0] = %v, want %v", samples[0], first)
+	}
+	if math.Abs(samples[n-1].X-last.X) > 1e-9 || math.Abs(samples[n-1].Y-last.Y) > 1e-9 {
+		t.Errorf("Samples()[%d] = %v, want %v", n-1, samples[n-1], last)
+	}
+	// A chord is never longer than the arc it spans, and on this gently curving path it stays
+	// within 10% of it, so each chord should be close to an equal share of the total length.
+	step := s.TotalLength() / float64(n-1)
+	for i := 1; i < n; i++ {
+		if chord := samples[i].Add(samples[i-1].Scale(-1)).Len(); chord > 1.01*step || chord < 0.9*step {
+			t.Errorf("Samples() chord %d = %v, want close to the arc-length step %v", i, chord, step)
+		}
+	}
+}
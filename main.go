@@ -1,12 +1,15 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"math"
-	"math/rand"
+	"os"
 	"time"
 
+	"github.com/lnsp/pdi-carsim/control"
 	"github.com/lnsp/pdi-carsim/geometry"
+	"github.com/lnsp/pdi-carsim/sim"
 	"github.com/veandco/go-sdl2/sdl"
 )
 
@@ -15,121 +18,130 @@ const (
 )
 
 func main() {
-	if err := run(); err != nil {
+	render := flag.Bool("render", false, "open an SDL window and drive the car interactively")
+	replay := flag.String("replay", "", "replay a previously recorded trace file in an SDL window")
+	seed := flag.Int64("seed", 1, "seed for the deterministic path generator")
+	steps := flag.Int("steps", 1000, "number of fixed-timestep steps to run in headless mode")
+	dt := flag.Float64("dt", 1.0/60.0, "fixed timestep used in headless mode, in seconds")
+	trace := flag.String("trace", "", "write a JSON-lines trace of the headless run to this file")
+	flag.Parse()
+
+	var err error
+	switch {
+	case *replay != "":
+		err = runReplay(*replay)
+	case *render:
+		err = runInteractive(*seed)
+	default:
+		err = runHeadless(*seed, *steps, *dt, *trace)
+	}
+	if err != nil {
 		panic(err)
 	}
 }
 
-// CarModel is an abstract physics model of a car.
-type CarModel struct {
-	Size, Position, Velocity, Acceleration geometry.Vector
-	Bounds                                 geometry.Polygon
-	Mass, Rotation, Tension, Sensitivity   float64
-}
-
-// ApplyForce applies a force to the car.
-func (car *CarModel) ApplyForce(f geometry.Vector) {
-	car.Acceleration = car.Acceleration.Add(f.Scale(1.0 / car.Mass))
-}
-
-// Accelerate accelerates the car by the specified factor.
-func (car *CarModel) Accelerate(delta float64) {
-	car.ApplyForce(geometry.X.Scale(delta))
-}
-
-// Break stops the cars movement.
-func (car *CarModel) Break(delta float64) {
-	car.Acceleration = car.Acceleration.Scale(car.Tension / car.Sensitivity)
-	car.Velocity = car.Velocity.Scale(car.Tension)
-}
-
-// Turn turns the wheel
-func (car *CarModel) Turn(delta float64) {
-	car.Rotation += delta * car.Sensitivity
-}
-
-// NewCar initializes a new car model.
-func NewCar(mass, x, y, width, height float64) *CarModel {
-	car := &CarModel{
-		Size:         geometry.Vector{X: width, Y: height},
-		Position:     geometry.Vector{X: x, Y: y},
-		Velocity:     geometry.NullVector,
-		Acceleration: geometry.NullVector,
-		Mass:         mass,
-		Rotation:     0,
-		Tension:      0.9999,
-		Sensitivity:  50.,
+func defaultProfile() sim.CarProfile {
+	return sim.CarProfile{
+		Mass:              1200,
+		Drag:              0.35,
+		RollingResistance: 30,
+		CGHeight:          0.5,
+		FrontAxle:         1.2,
+		RearAxle:          1.3,
+		TireStiffness:     60000,
+		TireFriction:      1.0,
+		YawInertia:        1500,
+		MaxEngineForce:    6000,
+		MaxBrakeForce:     9000,
+		MaxSteer:          math.Pi / 6,
 	}
-	car.Bounds = geometry.NewPolygon(geometry.NullVector, geometry.NullVector.AddX(car.Size), car.Size, geometry.NullVector.AddY(car.Size))
-	return car
-}
-
-func (car *CarModel) TurnCenter() geometry.Vector {
-	return car.Bounds.Translate(geometry.Null.AddX(car.Size.Scale(-0.2))).Center()
-}
-
-// Update updates the car model.
-func (car *CarModel) Update(delta float64) {
-	car.Acceleration = car.Acceleration.Scale(car.Tension)
-	car.Velocity = car.Velocity.Add(car.Acceleration.Scale(delta))
-	car.Position = car.Position.Add(car.Velocity.Scale(delta).RotateAround(geometry.Null, car.Rotation))
 }
 
-// Draw renders the model onto the screen.
-func (car *CarModel) Draw(r *sdl.Renderer) {
-	// Rotated = Model.RotateAround(Center, Rotation)
-	// Translated = Rotated.Translate(Car.Position)
-	vertices := car.Bounds.RotateAround(car.TurnCenter(), car.Rotation).Translate(car.Position).Points()
-	r.DrawLines(vertices)
-}
-
-type CarController interface {
-	Feed(float64, geometry.Vector)
+func defaultController(maxSteer float64) control.CarController {
+	return &control.PurePursuitController{
+		LookaheadGain: 0.5,
+		LookaheadBase: 40,
+		MaxSteer:      maxSteer,
+	}
 }
 
-type SimpleCarControl struct {
-	*CarModel
+// runHeadless steps a simulation deterministically, without opening an SDL window, and
+// optionally records its trace to tracePath.
+func runHeadless(seed int64, steps int, dt float64, tracePath string) error {
+	profile := defaultProfile()
+	s := sim.NewSimulation(seed, profile, defaultController(profile.MaxSteer))
+
+	var record func(sim.TraceEntry) error
+	if tracePath != "" {
+		f, err := os.Create(tracePath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w := sim.NewTraceWriter(f)
+		record = w.Write
+	}
+	return sim.Run(s, steps, dt, record)
 }
 
-func (ctrl *SimpleCarControl) Feed(delta float64, p geometry.Vector) {
-	diffVector := p.Add(ctrl.Position.Add(ctrl.TurnCenter()).Scale(-1))
-	diffAngle := diffVector.AngleBetween(ctrl.Velocity.Norm().RotateAround(geometry.Null, ctrl.Rotation))
-	fmt.Println(diffAngle)
-
-	if diffAngle > 0 {
-		ctrl.Turn(delta)
-	} else if diffAngle < 0 {
-		ctrl.Turn(-delta)
+// runReplay re-renders a previously recorded trace file in an SDL window, without re-running the
+// simulation.
+func runReplay(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	entries, err := sim.ReadTrace(f)
+	if err != nil {
+		return err
 	}
 
-	br := (-diffVector.Len() + 100)
-	of := math.Log(diffVector.Len()-30) / 10
-
-	if of > 0 {
-		ctrl.Accelerate(0.1)
+	if err := sdl.Init(sdl.INIT_EVERYTHING); err != nil {
+		return err
 	}
-	if br > 0 {
-		ctrl.Break(br)
+	defer sdl.Quit()
+	window, renderer, err := sdl.CreateWindowAndRenderer(windowWidth, windowHeight, sdl.WINDOW_SHOWN)
+	if err != nil {
+		return err
 	}
-}
+	defer window.Destroy()
+	defer renderer.Destroy()
 
-func generateRandomPath(c int, x, y, width, height float64) geometry.Polygon {
-	rand.Seed(time.Now().Unix())
-	vertices := make([]geometry.Vector, c)
-	for i := range vertices {
-		vertices[i] = geometry.Vector{
-			X: rand.Float64()*width + x,
-			Y: rand.Float64()*height + y,
+	trail := make([]sdl.Point, 0, len(entries))
+	lastT := 0.0
+	for _, entry := range entries {
+		for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
+			if ke, ok := event.(*sdl.KeyDownEvent); ok && ke.Keysym.Sym == sdl.K_ESCAPE {
+				return nil
+			}
 		}
+
+		position := geometry.Vector{X: entry.X, Y: entry.Y}
+		heading := geometry.X.RotateAround(geometry.Null, entry.Heading).Scale(20)
+		trail = append(trail, position.ToPoint())
+
+		renderer.SetDrawColor(0, 0, 0, 255)
+		renderer.Clear()
+		renderer.SetDrawColor(0, 255, 0, 255)
+		renderer.DrawLines(trail)
+		renderer.SetDrawColor(255, 0, 0, 255)
+		renderer.DrawLine(position.ToPoint(), position.Add(heading).ToPoint())
+		renderer.Present()
+
+		sdl.Delay(uint32(math.Max(0, entry.T-lastT) * 1000))
+		lastT = entry.T
 	}
-	return geometry.NewPolygon(vertices...)
+	return nil
 }
 
-func run() error {
-	err := sdl.Init(sdl.INIT_EVERYTHING)
-	if err != nil {
+// runInteractive opens an SDL window and drives the car either under keyboard control or the
+// default autonomous controller, toggled with the 'o' key.
+func runInteractive(seed int64) error {
+	if err := sdl.Init(sdl.INIT_EVERYTHING); err != nil {
 		return err
 	}
+	defer sdl.Quit()
 	window, renderer, err := sdl.CreateWindowAndRenderer(windowWidth, windowHeight, sdl.WINDOW_SHOWN)
 	if err != nil {
 		return err
@@ -137,26 +149,26 @@ func run() error {
 	defer window.Destroy()
 	defer renderer.Destroy()
 
-	targetPath := generateRandomPath(8, 100, 100, 1000, 600)
-	car := NewCar(1, 100, 100, 100, 50)
+	profile := defaultProfile()
+	s := sim.NewSimulation(seed, profile, defaultController(profile.MaxSteer))
+	car := s.Car
+
 	path := []sdl.Point{car.TurnCenter().ToPoint()}
 	lastFrame, lastPathUpdate := time.Now(), time.Now()
-	ctrl := SimpleCarControl{car}
 
-	progress := 2.0
 	ownControl := false
+	wallsEnabled := false
 	for {
 		renderer.SetDrawColor(0, 0, 0, 255)
 		renderer.Clear()
 		renderer.SetDrawColor(0, 0, 255, 255)
-		renderer.DrawLines(targetPath.Points())
+		renderer.DrawLines(s.Path.Points())
 		renderer.SetDrawColor(0, 255, 0, 255)
 		renderer.DrawLines(path)
 		renderer.SetDrawColor(255, 0, 0, 255)
-		car.Draw(renderer)
+		renderer.DrawLines(car.WorldBounds().Points())
 
 		delta := float64(time.Since(lastFrame)) / float64(time.Second)
-		car.Update(delta)
 		lastFrame = time.Now()
 
 		if time.Since(lastPathUpdate) > time.Second/10 {
@@ -164,16 +176,6 @@ func run() error {
 			lastPathUpdate = time.Now()
 		}
 
-		progress += delta / 60
-		if progress > 1.0 {
-			targetPath = generateRandomPath(8, 100, 100, 1000, 600)
-			progress = 0.0
-			car.Position = targetPath.Interpolate(0.0).Add(car.TurnCenter().Scale(-1))
-			path = []sdl.Point{car.Position.ToPoint()}
-		}
-		interpol := targetPath.Interpolate(progress).ToPoint()
-		renderer.DrawPoints([]sdl.Point{interpol})
-
 		for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
 			switch et := event.(type) {
 			case *sdl.KeyDownEvent:
@@ -181,22 +183,39 @@ func run() error {
 				case sdl.K_ESCAPE:
 					return nil
 				case sdl.K_w:
-					car.Accelerate(10.0)
+					car.Accelerate(1.0)
 				case sdl.K_s:
-					car.Break(10.0)
+					car.Break(1.0)
 				case sdl.K_a:
-					car.Turn(math.Pi * 4 * delta)
+					car.Turn(car.Profile.MaxSteer)
 				case sdl.K_d:
-					car.Turn(-math.Pi * 4 * delta)
+					car.Turn(-car.Profile.MaxSteer)
 				case sdl.K_o:
 					ownControl = !ownControl
+				case sdl.K_c:
+					wallsEnabled = !wallsEnabled
+				case sdl.K_p:
+					switch s.Controller.(type) {
+					case *control.PurePursuitController:
+						s.Controller = &control.StanleyController{Gain: 1.0, Softening: 1.0, MaxSteer: profile.MaxSteer}
+					default:
+						s.Controller = defaultController(profile.MaxSteer)
+					}
 				}
 			}
 		}
-		if !ownControl {
-			ctrl.Feed(delta, targetPath.Interpolate(progress))
+
+		s.WallsEnabled = wallsEnabled
+		if ownControl {
+			car.Update(delta)
+			if wallsEnabled {
+				car.CollideWith(s.Path)
+			}
+		} else {
+			s.Step(delta)
 		}
 
+		fmt.Fprintf(os.Stdout, "\rt=%7.2f x=%7.1f y=%7.1f heading=%5.2f", s.Time, car.Position.X, car.Position.Y, car.Heading)
 		renderer.Present()
 	}
 }
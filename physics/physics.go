@@ -0,0 +1,52 @@
+// Package physics resolves rigid-body collisions between the car and static world obstacles.
+package physics
+
+import "github.com/lnsp/pdi-carsim/geometry"
+
+// Body is a rigid body expressed in world space, as needed to resolve a single collision.
+type Body struct {
+	Position        geometry.Vector // world position of the body's reference point
+	Velocity        geometry.Vector // world-space linear velocity of Position
+	AngularVelocity float64         // yaw rate, radians per second
+	Mass            float64
+	Inertia         float64          // moment of inertia about the yaw axis, through Position
+	Restitution     float64          // coefficient of restitution, 0 (inelastic) to 1 (elastic)
+	Bounds          geometry.Polygon // world-space collision polygon
+}
+
+// ResolveCollision tests Body against a static convex obstacle and, if they overlap, pushes the
+// body out along the MTV and applies a collision impulse at the contact point, updating its
+// Position, Bounds, Velocity and AngularVelocity in place. It reports whether a collision occurred.
+func ResolveCollision(body *Body, obstacle geometry.Polygon) bool {
+	overlap, normal, colliding := body.Bounds.SAT(obstacle)
+	if !colliding {
+		return false
+	}
+	if body.Bounds.Center().Add(obstacle.Center().Scale(-1)).Dot(normal) < 0 {
+		normal = normal.Scale(-1)
+	}
+
+	correction := normal.Scale(overlap)
+	body.Position = body.Position.Add(correction)
+	body.Bounds = body.Bounds.Translate(correction)
+
+	_, _, contact := obstacle.NearestPoint(body.Bounds.Center())
+	r := contact.Add(body.Position.Scale(-1))
+
+	velocityAtContact := body.Velocity.Add(geometry.Vector{X: -body.AngularVelocity * r.Y, Y: body.AngularVelocity * r.X})
+	relativeVelocity := velocityAtContact.Dot(normal)
+	if relativeVelocity >= 0 {
+		// Already separating along the normal; the positional correction above is enough.
+		return true
+	}
+
+	rCrossN := r.Det(normal)
+	invMass, invInertia := 1/body.Mass, 1/body.Inertia
+	impulseMagnitude := -(1 + body.Restitution) * relativeVelocity / (invMass + rCrossN*rCrossN*invInertia)
+
+	impulse := normal.Scale(impulseMagnitude)
+	body.Velocity = body.Velocity.Add(impulse.Scale(invMass))
+	body.AngularVelocity += rCrossN * impulseMagnitude * invInertia
+
+	return true
+}
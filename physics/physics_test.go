@@ -0,0 +1,80 @@
+package physics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lnsp/pdi-carsim/geometry"
+)
+
+func square(cx, cy float64) geometry.Polygon {
+	return geometry.NewPolygon(
+		geometry.Vector{X: cx - 1, Y: cy - 1},
+		geometry.Vector{X: cx + 1, Y: cy - 1},
+		geometry.Vector{X: cx + 1, Y: cy + 1},
+		geometry.Vector{X: cx - 1, Y: cy + 1},
+	)
+}
+
+func TestResolveCollisionInelasticStopsBody(t *testing.T) {
+	obstacle := square(2, 1)
+	body := &Body{
+		Position:    geometry.Vector{X: 1, Y: 1},
+		Velocity:    geometry.Vector{X: 1, Y: 0},
+		Mass:        1,
+		Inertia:     1,
+		Restitution: 0,
+		Bounds:      square(1, 1),
+	}
+
+	if colliding := ResolveCollision(body, obstacle); !colliding {
+		t.Fatalf("ResolveCollision() = false, want true for overlapping squares")
+	}
+
+	if math.Abs(body.Velocity.X) > 1e-9 || math.Abs(body.Velocity.Y) > 1e-9 {
+		t.Errorf("Velocity = %v, want (0, 0) after an inelastic head-on collision", body.Velocity)
+	}
+	if math.Abs(body.Position.X-0) > 1e-9 || math.Abs(body.Position.Y-1) > 1e-9 {
+		t.Errorf("Position = %v, want (0, 1) after MTV correction", body.Position)
+	}
+	if overlap, _, colliding := body.Bounds.SAT(obstacle); colliding {
+		t.Errorf("Bounds still overlap obstacle after correction (overlap=%v)", overlap)
+	}
+}
+
+func TestResolveCollisionElasticBounces(t *testing.T) {
+	obstacle := square(2, 1)
+	body := &Body{
+		Position:    geometry.Vector{X: 1, Y: 1},
+		Velocity:    geometry.Vector{X: 1, Y: 0},
+		Mass:        1,
+		Inertia:     1,
+		Restitution: 1,
+		Bounds:      square(1, 1),
+	}
+
+	ResolveCollision(body, obstacle)
+
+	if math.Abs(body.Velocity.X+1) > 1e-9 || math.Abs(body.Velocity.Y) > 1e-9 {
+		t.Errorf("Velocity = %v, want (-1, 0) after a fully elastic head-on collision", body.Velocity)
+	}
+}
+
+func TestResolveCollisionNoOverlap(t *testing.T) {
+	obstacle := square(10, 1)
+	body := &Body{
+		Position: geometry.Vector{X: 1, Y: 1},
+		Velocity: geometry.Vector{X: 1, Y: 0},
+		Mass:     1,
+		Inertia:  1,
+		Bounds:   square(1, 1),
+	}
+	wantPosition, wantVelocity := body.Position, body.Velocity
+
+	if colliding := ResolveCollision(body, obstacle); colliding {
+		t.Errorf("ResolveCollision() = true, want false for separated squares")
+	}
+	if body.Position != wantPosition || body.Velocity != wantVelocity {
+		t.Errorf("ResolveCollision() mutated body (position=%v, velocity=%v) when no collision occurred", body.Position, body.Velocity)
+	}
+}
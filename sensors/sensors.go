@@ -0,0 +1,115 @@
+// Package sensors produces observations from the simulated world for use by learning/AI
+// controllers, standing in for the sensing a real car's perception stack would provide.
+package sensors
+
+import (
+	"math/rand"
+
+	"github.com/lnsp/pdi-carsim/geometry"
+	"github.com/lnsp/pdi-carsim/sim"
+)
+
+// Lidar simulates a rotating raycast rangefinder mounted on the car.
+type Lidar struct {
+	NumRays  int
+	MaxRange float64
+	FOV      float64 // field of view, in radians, centered on the car's heading
+}
+
+// Sample casts NumRays evenly spaced rays across the FOV, relative to the car's heading, from the
+// car's turn center, and returns the distance to the nearest edge of obstacles each ray hits, or
+// MaxRange if it hits nothing.
+func (l *Lidar) Sample(car *sim.CarModel, obstacles []geometry.Polygon) []float64 {
+	origin := car.Position.Add(car.TurnCenter())
+	distances := make([]float64, l.NumRays)
+	for i := range distances {
+		angle := car.Heading + l.rayAngle(i)
+		direction := geometry.X.RotateAround(geometry.Null, angle)
+		ray := geometry.Seg2{A: origin, B: origin.Add(direction.Scale(l.MaxRange))}
+
+		distances[i] = l.MaxRange
+		for _, obstacle := range obstacles {
+			n := len(obstacle)
+			for e := 0; e < n; e++ {
+				edge := geometry.Seg2{A: obstacle[e], B: obstacle[(e+1)%n]}
+				hit, ok := ray.Intersect(edge)
+				if !ok {
+					continue
+				}
+				if d := hit.Add(origin.Scale(-1)).Len(); d < distances[i] {
+					distances[i] = d
+				}
+			}
+		}
+	}
+	return distances
+}
+
+// rayAngle returns the angular offset, relative to the car's heading, of ray i.
+func (l *Lidar) rayAngle(i int) float64 {
+	if l.NumRays <= 1 {
+		return 0
+	}
+	return l.FOV*(float64(i)/float64(l.NumRays-1)) - l.FOV/2
+}
+
+// OdometrySensor reports the car's forward velocity and yaw rate with additive Gaussian noise,
+// as a wheel-encoder/IMU based odometry stack would.
+type OdometrySensor struct {
+	VelocityNoise float64 // standard deviation of the velocity noise
+	YawRateNoise  float64 // standard deviation of the yaw rate noise
+
+	rng *rand.Rand
+}
+
+// NewOdometrySensor builds an OdometrySensor with deterministic noise driven by seed.
+func NewOdometrySensor(seed int64, velocityNoise, yawRateNoise float64) *OdometrySensor {
+	return &OdometrySensor{
+		VelocityNoise: velocityNoise,
+		YawRateNoise:  yawRateNoise,
+		rng:           rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Sample returns the car's forward velocity and yaw rate, each perturbed by independent Gaussian
+// noise.
+func (o *OdometrySensor) Sample(car *sim.CarModel) (v, yawRate float64) {
+	v = car.Velocity + o.rng.NormFloat64()*o.VelocityNoise
+	yawRate = car.YawRate + o.rng.NormFloat64()*o.YawRateNoise
+	return v, yawRate
+}
+
+// PathProgressSensor reports how far the car has drifted from a reference path.
+type PathProgressSensor struct{}
+
+// Sample returns the signed cross-track error and heading error of the car's rear axle against
+// the nearest segment of path, using the same sign convention as control.StanleyController.
+func (PathProgressSensor) Sample(car *sim.CarModel, path geometry.Polygon) (crossTrackError, headingError float64) {
+	rearAxle := car.Position.Add(car.TurnCenter())
+	n := len(path)
+	segIdx, _, nearest := path.NearestPoint(rearAxle)
+	a, b := path[segIdx], path[(segIdx+1)%n]
+	direction := b.Add(a.Scale(-1)).Norm()
+
+	crossTrackError = direction.Det(rearAxle.Add(nearest.Scale(-1)))
+	forward := geometry.X.RotateAround(geometry.Null, car.Heading)
+	headingError = direction.AngleBetween(forward)
+	return crossTrackError, headingError
+}
+
+// Observation bundles the sensor readings available to a SensingController for a single control
+// step.
+type Observation struct {
+	Lidar           []float64
+	Velocity        float64
+	YawRate         float64
+	CrossTrackError float64
+	HeadingError    float64
+}
+
+// SensingController is a CarController variant driven entirely by sensor observations rather than
+// direct access to the simulation state, so external RL agents can be plugged in behind a stable
+// interface.
+type SensingController interface {
+	Control(obs Observation) sim.Controls
+}
@@ -0,0 +1,90 @@
+package sensors
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lnsp/pdi-carsim/geometry"
+	"github.com/lnsp/pdi-carsim/sim"
+)
+
+func testCar() *sim.CarModel {
+	return sim.NewCar(sim.CarProfile{MaxSteer: math.Pi / 6}, 0, 0, 100, 50)
+}
+
+func TestLidarSampleHitsObstacleAhead(t *testing.T) {
+	car := testCar()
+	origin := car.Position.Add(car.TurnCenter())
+	const distance = 40.0
+
+	// A two-point polygon degenerates to a single edge, which is enough to raycast against.
+	obstacle := geometry.NewPolygon(
+		geometry.Vector{X: origin.X + distance, Y: origin.Y - 10},
+		geometry.Vector{X: origin.X + distance, Y: origin.Y + 10},
+	)
+	l := &Lidar{NumRays: 1, MaxRange: 1000, FOV: 0}
+
+	got := l.Sample(car, []geometry.Polygon{obstacle})
+	if len(got) != 1 {
+		t.Fatalf("Sample() returned %d distances, want 1", len(got))
+	}
+	if math.Abs(got[0]-distance) > 1e-6 {
+		t.Errorf("Sample()[0] = %v, want %v", got[0], distance)
+	}
+}
+
+func TestLidarSampleMissesWithNoObstacles(t *testing.T) {
+	car := testCar()
+	l := &Lidar{NumRays: 1, MaxRange: 500, FOV: 0}
+
+	got := l.Sample(car, nil)
+	if len(got) != 1 || got[0] != l.MaxRange {
+		t.Errorf("Sample() = %v, want [%v] with no obstacles", got, l.MaxRange)
+	}
+}
+
+func TestOdometrySensorIsDeterministicForASeed(t *testing.T) {
+	car := testCar()
+	car.Velocity = 10
+	car.YawRate = 0.2
+
+	a := NewOdometrySensor(1, 0.5, 0.05)
+	b := NewOdometrySensor(1, 0.5, 0.05)
+
+	for i := 0; i < 5; i++ {
+		av, ay := a.Sample(car)
+		bv, by := b.Sample(car)
+		if av != bv || ay != by {
+			t.Fatalf("sample %d diverged between sensors seeded identically: (%v, %v) vs (%v, %v)", i, av, ay, bv, by)
+		}
+	}
+}
+
+func TestOdometrySensorAddsNoise(t *testing.T) {
+	car := testCar()
+	car.Velocity = 10
+	car.YawRate = 0
+
+	o := NewOdometrySensor(1, 1, 1)
+	v, yawRate := o.Sample(car)
+	if v == car.Velocity && yawRate == car.YawRate {
+		t.Errorf("Sample() = (%v, %v), want noise applied to at least one reading", v, yawRate)
+	}
+}
+
+func TestPathProgressSensorCrossTrackError(t *testing.T) {
+	car := testCar()
+	car.Position = geometry.Vector{X: 0, Y: 5}
+	car.Heading = 0
+
+	path := geometry.NewPolygon(geometry.Vector{X: -100, Y: 0}, geometry.Vector{X: 100, Y: 0})
+
+	var s PathProgressSensor
+	crossTrack, headingError := s.Sample(car, path)
+	if math.Abs(headingError) > 1e-9 {
+		t.Errorf("headingError = %v, want ~0 when parallel to the path", headingError)
+	}
+	if crossTrack == 0 {
+		t.Errorf("crossTrackError = 0, want nonzero when offset from the path")
+	}
+}
@@ -0,0 +1,177 @@
+// Package sim contains the car's physics model, the path-following stepping loop and the
+// deterministic headless scheduler. It makes no SDL calls, so it can run (and be tested)
+// without an SDL window.
+package sim
+
+import (
+	"math"
+
+	"github.com/lnsp/pdi-carsim/geometry"
+	"github.com/lnsp/pdi-carsim/physics"
+)
+
+const gravity = 9.81
+
+// wallRestitution is the coefficient of restitution used when the car collides with a wall.
+const wallRestitution = 0.2
+
+// CarProfile carries the physical parameters of a vehicle used by the bicycle model.
+type CarProfile struct {
+	Mass              float64 // vehicle mass
+	Drag              float64 // aerodynamic drag coefficient
+	RollingResistance float64 // rolling resistance coefficient
+	CGHeight          float64 // height of the center of gravity above the ground
+	FrontAxle         float64 // distance from the center of gravity to the front axle
+	RearAxle          float64 // distance from the center of gravity to the rear axle
+	TireStiffness     float64 // cornering stiffness, shared by the front and rear tires
+	TireFriction      float64 // friction coefficient bounding lateral tire force by axle load
+	YawInertia        float64 // moment of inertia around the yaw axis
+	MaxEngineForce    float64 // maximum longitudinal force the engine can apply
+	MaxBrakeForce     float64 // maximum longitudinal force the brakes can apply
+	MaxSteer          float64 // maximum steering angle in radians
+}
+
+// Wheelbase returns the distance between the front and rear axle.
+func (p CarProfile) Wheelbase() float64 {
+	return p.FrontAxle + p.RearAxle
+}
+
+// Controls holds the driver inputs applied to a CarModel.
+type Controls struct {
+	Throttle float64 // [0, 1]
+	Brake    float64 // [0, 1]
+	Steering float64 // [-Profile.MaxSteer, Profile.MaxSteer]
+}
+
+// CarModel is a 2D bicycle model of a car with slip-angle based tire forces.
+type CarModel struct {
+	Profile  CarProfile
+	Size     geometry.Vector
+	Bounds   geometry.Polygon
+	Controls Controls
+
+	Position        geometry.Vector
+	Heading         float64 // u, heading angle in radians
+	Velocity        float64 // v, forward velocity in the car's frame
+	LateralVelocity float64 // lateral velocity in the car's frame, needed for the slip angles
+	YawRate         float64 // rear-wheel angular velocity (yaw rate) omega
+	Acceleration    float64 // transient longitudinal acceleration
+	Steering        float64 // current steering angle, clamped to Profile.MaxSteer
+}
+
+// NewCar initializes a new car model with the given profile.
+func NewCar(profile CarProfile, x, y, width, height float64) *CarModel {
+	car := &CarModel{
+		Profile:  profile,
+		Size:     geometry.Vector{X: width, Y: height},
+		Position: geometry.Vector{X: x, Y: y},
+	}
+	car.Bounds = geometry.NewPolygon(geometry.NullVector, geometry.NullVector.AddX(car.Size), car.Size, geometry.NullVector.AddY(car.Size))
+	return car
+}
+
+func (car *CarModel) TurnCenter() geometry.Vector {
+	return car.Bounds.Translate(geometry.Null.AddX(car.Size.Scale(-0.2))).Center()
+}
+
+// Accelerate sets the throttle control, clamped to [0, 1].
+func (car *CarModel) Accelerate(throttle float64) {
+	car.Controls.Throttle = clamp(throttle, 0, 1)
+}
+
+// Break sets the brake control, clamped to [0, 1].
+func (car *CarModel) Break(brake float64) {
+	car.Controls.Brake = clamp(brake, 0, 1)
+}
+
+// Turn sets the steering control, clamped to [-Profile.MaxSteer, Profile.MaxSteer].
+func (car *CarModel) Turn(steer float64) {
+	car.Controls.Steering = clamp(steer, -car.Profile.MaxSteer, car.Profile.MaxSteer)
+}
+
+// Update advances the bicycle model by dt seconds.
+func (car *CarModel) Update(dt float64) {
+	p := car.Profile
+
+	engineForce := car.Controls.Throttle * p.MaxEngineForce
+	brakeForce := car.Controls.Brake * p.MaxBrakeForce * sign(car.Velocity)
+	dragForce := -p.Drag * car.Velocity * math.Abs(car.Velocity)
+	rollingForce := -p.RollingResistance * car.Velocity
+	car.Acceleration = (engineForce - brakeForce + dragForce + rollingForce) / p.Mass
+
+	car.Steering = clamp(car.Controls.Steering, -p.MaxSteer, p.MaxSteer)
+
+	alphaF := math.Atan2(car.LateralVelocity+car.YawRate*p.FrontAxle, car.Velocity) - car.Steering
+	alphaR := math.Atan2(car.LateralVelocity-car.YawRate*p.RearAxle, car.Velocity)
+
+	// Load transfer from the longitudinal acceleration shifts weight between the axles.
+	wheelbase := p.Wheelbase()
+	weight := p.Mass * gravity
+	loadFront := math.Max(0, weight*p.RearAxle/wheelbase-p.Mass*car.Acceleration*p.CGHeight/wheelbase)
+	loadRear := math.Max(0, weight*p.FrontAxle/wheelbase+p.Mass*car.Acceleration*p.CGHeight/wheelbase)
+
+	forceFront := clamp(-p.TireStiffness*alphaF, -p.TireFriction*loadFront, p.TireFriction*loadFront)
+	forceRear := clamp(-p.TireStiffness*alphaR, -p.TireFriction*loadRear, p.TireFriction*loadRear)
+
+	lateralAccel := (forceFront*math.Cos(car.Steering)+forceRear)/p.Mass - car.Velocity*car.YawRate
+	yawAccel := (p.FrontAxle*forceFront*math.Cos(car.Steering) - p.RearAxle*forceRear) / p.YawInertia
+
+	// Semi-implicit Euler: integrate the velocities first, then advance position and heading
+	// using the values that already include this step's acceleration.
+	car.Velocity += car.Acceleration * dt
+	car.LateralVelocity += lateralAccel * dt
+	car.YawRate += yawAccel * dt
+	car.Heading += car.YawRate * dt
+
+	bodyVelocity := geometry.Vector{X: car.Velocity, Y: car.LateralVelocity}
+	car.Position = car.Position.Add(bodyVelocity.RotateAround(geometry.Null, car.Heading).Scale(dt))
+}
+
+// WorldBounds returns the car's Bounds polygon transformed into world space.
+func (car *CarModel) WorldBounds() geometry.Polygon {
+	return car.Bounds.RotateAround(car.TurnCenter(), car.Heading).Translate(car.Position)
+}
+
+// WorldVelocity returns the car's body-frame velocity expressed in world space.
+func (car *CarModel) WorldVelocity() geometry.Vector {
+	return geometry.Vector{X: car.Velocity, Y: car.LateralVelocity}.RotateAround(geometry.Null, car.Heading)
+}
+
+// CollideWith resolves a rigid-body collision between the car and a static polygon obstacle,
+// such as a wall, and reports whether a collision occurred.
+func (car *CarModel) CollideWith(obstacle geometry.Polygon) bool {
+	body := &physics.Body{
+		Position:        car.Position,
+		Velocity:        car.WorldVelocity(),
+		AngularVelocity: car.YawRate,
+		Mass:            car.Profile.Mass,
+		Inertia:         car.Profile.YawInertia,
+		Restitution:     wallRestitution,
+		Bounds:          car.WorldBounds(),
+	}
+	if !physics.ResolveCollision(body, obstacle) {
+		return false
+	}
+	car.Position = body.Position
+	car.YawRate = body.AngularVelocity
+	// RotateAround(Null, angle) is its own inverse, so the same transform that took the body-frame
+	// velocity into world space also takes it back.
+	local := body.Velocity.RotateAround(geometry.Null, car.Heading)
+	car.Velocity, car.LateralVelocity = local.X, local.Y
+	return true
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Min(hi, math.Max(lo, v))
+}
+
+func sign(v float64) float64 {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,75 @@
+package sim
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUpdateThrottleAcceleratesFromRest(t *testing.T) {
+	car := NewCar(testProfile(), 0, 0, 100, 50)
+	car.Accelerate(1)
+	car.Update(0.1)
+
+	if car.Velocity <= 0 {
+		t.Fatalf("Velocity = %v, want > 0 after applying throttle from rest", car.Velocity)
+	}
+	want := (car.Profile.MaxEngineForce / car.Profile.Mass) * 0.1
+	if math.Abs(car.Velocity-want) > 1e-6 {
+		t.Errorf("Velocity = %v, want %v (engine force alone, since drag and rolling resistance are 0 at v=0)", car.Velocity, want)
+	}
+}
+
+func TestUpdateSteeringAtSpeedProducesYawRate(t *testing.T) {
+	car := NewCar(testProfile(), 0, 0, 100, 50)
+	car.Velocity = 10
+	car.Turn(0.1)
+	car.Update(0.05)
+
+	if car.YawRate <= 0 {
+		t.Errorf("YawRate = %v, want > 0 after steering left at speed", car.YawRate)
+	}
+}
+
+func TestUpdateSteeringSignMatchesYawRateSign(t *testing.T) {
+	left := NewCar(testProfile(), 0, 0, 100, 50)
+	left.Velocity = 10
+	left.Turn(0.1)
+	left.Update(0.05)
+
+	right := NewCar(testProfile(), 0, 0, 100, 50)
+	right.Velocity = 10
+	right.Turn(-0.1)
+	right.Update(0.05)
+
+	if math.Signbit(left.YawRate) == math.Signbit(right.YawRate) {
+		t.Errorf("YawRate = %v (steer 0.1), %v (steer -0.1), want opposite signs", left.YawRate, right.YawRate)
+	}
+}
+
+func TestUpdateDragAndRollingResistanceDecelerateACoastingCar(t *testing.T) {
+	car := NewCar(testProfile(), 0, 0, 100, 50)
+	car.Velocity = 20
+
+	for i := 0; i < 60; i++ {
+		car.Update(1.0 / 60.0)
+	}
+
+	if car.Velocity <= 0 || car.Velocity >= 20 {
+		t.Errorf("Velocity = %v, want in (0, 20) after coasting for a second under drag and rolling resistance", car.Velocity)
+	}
+}
+
+func TestUpdateBrakeDeceleratesFasterThanCoasting(t *testing.T) {
+	coasting := NewCar(testProfile(), 0, 0, 100, 50)
+	coasting.Velocity = 20
+	coasting.Update(0.1)
+
+	braking := NewCar(testProfile(), 0, 0, 100, 50)
+	braking.Velocity = 20
+	braking.Break(1)
+	braking.Update(0.1)
+
+	if braking.Velocity >= coasting.Velocity {
+		t.Errorf("Velocity with brakes = %v, want less than coasting Velocity = %v", braking.Velocity, coasting.Velocity)
+	}
+}
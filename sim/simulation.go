@@ -0,0 +1,130 @@
+package sim
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/lnsp/pdi-carsim/control"
+	"github.com/lnsp/pdi-carsim/geometry"
+)
+
+// baseCruiseThrottle is the throttle applied while the car drives itself along Path, before the
+// curvature-based slowdown in cruiseThrottle.
+const baseCruiseThrottle = 0.3
+
+// curvatureSlowdownGain controls how sharply cruiseThrottle backs off in tight corners: throttle
+// is divided by 1+curvatureSlowdownGain*|curvature|.
+const curvatureSlowdownGain = 2.0
+
+// pathSamplesPerPoint is how densely GenerateRandomPath resamples its smoothing spline, per
+// random control point.
+const pathSamplesPerPoint = 16
+
+// Simulation bundles a car, its path-following controller and the target path it steers
+// toward into a single fixed-timestep stepping loop.
+type Simulation struct {
+	Car          *CarModel
+	Controller   control.CarController
+	Path         geometry.Polygon
+	Spline       *geometry.Spline // the smoothing spline Path was resampled from
+	WallsEnabled bool
+
+	Time float64
+}
+
+// NewSimulation builds a deterministic simulation: the target path and the car's start position
+// are derived entirely from seed, so the same seed always produces the same run.
+func NewSimulation(seed int64, profile CarProfile, controller control.CarController) *Simulation {
+	rng := rand.New(rand.NewSource(seed))
+	path, spline := GenerateRandomPath(rng, 8, 100, 100, 1000, 600)
+	car := NewCar(profile, 100, 100, 100, 50)
+	car.Position = path.Interpolate(0.0).Add(car.TurnCenter().Scale(-1))
+	return &Simulation{Car: car, Controller: controller, Path: path, Spline: spline}
+}
+
+// GenerateRandomPath scatters c random control points and smooths them with a Catmull-Rom spline,
+// so the path the controllers track has no sharp corners to chatter against. It returns both the
+// resampled Polygon the rest of the package queries and the underlying Spline, which callers need
+// for tangent/curvature.
+func GenerateRandomPath(rng *rand.Rand, c int, x, y, width, height float64) (geometry.Polygon, *geometry.Spline) {
+	vertices := make([]geometry.Vector, c)
+	for i := range vertices {
+		vertices[i] = geometry.Vector{
+			X: rng.Float64()*width + x,
+			Y: rng.Float64()*height + y,
+		}
+	}
+	spline := geometry.NewCatmullRomSpline(vertices, 0)
+	return spline.Samples(c * pathSamplesPerPoint), spline
+}
+
+// Step advances the simulation by dt seconds and returns a TraceEntry describing the resulting
+// state.
+func (s *Simulation) Step(dt float64) TraceEntry {
+	rearAxle := s.Car.Position.Add(s.Car.TurnCenter())
+	segIdx, t, _ := s.Path.NearestPoint(rearAxle)
+	state := control.CarState{
+		RearAxle:  rearAxle,
+		Heading:   s.Car.Heading,
+		Velocity:  s.Car.Velocity,
+		Wheelbase: s.Car.Profile.Wheelbase(),
+	}
+	s.Car.Turn(s.Controller.Steer(state, s.Path, s.Spline))
+	s.Car.Accelerate(s.cruiseThrottle(segIdx, t))
+	s.Car.Update(dt)
+	if s.WallsEnabled {
+		s.Car.CollideWith(s.Path)
+	}
+	s.Time += dt
+
+	// Measure the trace's path errors at the position the step ended at.
+	rearAxle = s.Car.Position.Add(s.Car.TurnCenter())
+	segIdx, t, _ = s.Path.NearestPoint(rearAxle)
+	worldVelocity := s.Car.WorldVelocity()
+
+	return TraceEntry{
+		T:               s.Time,
+		X:               s.Car.Position.X,
+		Y:               s.Car.Position.Y,
+		Heading:         s.Car.Heading,
+		VX:              worldVelocity.X,
+		VY:              worldVelocity.Y,
+		Steering:        s.Car.Steering,
+		Throttle:        s.Car.Controls.Throttle,
+		Brake:           s.Car.Controls.Brake,
+		CrossTrackError: crossTrackError(s.Path, segIdx, rearAxle),
+		PathProgress:    s.Path.ArcLengthAt(segIdx, t) / s.Path.TotalLength(),
+	}
+}
+
+// cruiseThrottle returns baseCruiseThrottle backed off in proportion to the path's curvature at
+// segment segIdx, parameter t, so the car slows down ahead of tight corners instead of chattering
+// through them at a constant speed.
+func (s *Simulation) cruiseThrottle(segIdx int, t float64) float64 {
+	u := (float64(segIdx) + t) / float64(len(s.Path)-1)
+	curvature := s.Spline.Curvature(u)
+	return baseCruiseThrottle / (1 + curvatureSlowdownGain*math.Abs(curvature))
+}
+
+// crossTrackError returns the signed distance of point from the path segment segIdx, using the
+// segment normal via Vector.Det, the same convention as control.StanleyController.
+func crossTrackError(path geometry.Polygon, segIdx int, point geometry.Vector) float64 {
+	n := len(path)
+	a, b := path[segIdx], path[(segIdx+1)%n]
+	direction := b.Add(a.Scale(-1)).Norm()
+	return direction.Det(point.Add(a.Scale(-1)))
+}
+
+// Run steps the simulation steps times at the fixed timestep dt, calling record with the
+// resulting TraceEntry after every step. record may be nil to run without recording a trace.
+func Run(s *Simulation, steps int, dt float64, record func(TraceEntry) error) error {
+	for i := 0; i < steps; i++ {
+		entry := s.Step(dt)
+		if record != nil {
+			if err := record(entry); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
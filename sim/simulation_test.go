@@ -0,0 +1,82 @@
+package sim
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lnsp/pdi-carsim/control"
+	"github.com/lnsp/pdi-carsim/geometry"
+)
+
+func testProfile() CarProfile {
+	return CarProfile{
+		Mass:              1200,
+		Drag:              0.35,
+		RollingResistance: 30,
+		CGHeight:          0.5,
+		FrontAxle:         1.2,
+		RearAxle:          1.3,
+		TireStiffness:     60000,
+		TireFriction:      1.0,
+		YawInertia:        1500,
+		MaxEngineForce:    6000,
+		MaxBrakeForce:     9000,
+		MaxSteer:          math.Pi / 6,
+	}
+}
+
+func newTestSimulation(seed int64) *Simulation {
+	profile := testProfile()
+	controller := &control.PurePursuitController{LookaheadGain: 0.5, LookaheadBase: 40, MaxSteer: profile.MaxSteer}
+	return NewSimulation(seed, profile, controller)
+}
+
+func recordTrace(s *Simulation, steps int, dt float64) []TraceEntry {
+	var entries []TraceEntry
+	Run(s, steps, dt, func(e TraceEntry) error {
+		entries = append(entries, e)
+		return nil
+	})
+	return entries
+}
+
+func TestRunIsDeterministicForAFixedSeed(t *testing.T) {
+	a := recordTrace(newTestSimulation(42), 50, 1.0/60.0)
+	b := recordTrace(newTestSimulation(42), 50, 1.0/60.0)
+
+	if len(a) != len(b) {
+		t.Fatalf("got trace lengths %d and %d, want equal", len(a), len(b))
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("step %d diverged between runs with the same seed:\n%+v\n%+v", i, a[i], b[i])
+		}
+	}
+}
+
+func TestRunDivergesAcrossSeeds(t *testing.T) {
+	a := recordTrace(newTestSimulation(1), 50, 1.0/60.0)
+	b := recordTrace(newTestSimulation(2), 50, 1.0/60.0)
+
+	if len(a) == 0 || len(b) == 0 {
+		t.Fatal("Run() produced an empty trace")
+	}
+	if a[len(a)-1] == b[len(b)-1] {
+		t.Errorf("runs with different seeds produced identical final state %+v", a[len(a)-1])
+	}
+}
+
+func TestCollideWithObstacleAhead(t *testing.T) {
+	s := newTestSimulation(7)
+	obstacle := s.Car.WorldBounds().Translate(geometry.Vector{X: s.Car.Profile.Wheelbase() + 1, Y: 0})
+	s.Car.Controls.Throttle = 1
+
+	collided := false
+	for i := 0; i < 200 && !collided; i++ {
+		s.Car.Update(1.0 / 60.0)
+		collided = s.Car.CollideWith(obstacle)
+	}
+	if !collided {
+		t.Fatalf("car never collided with an obstacle placed directly ahead of it")
+	}
+}
@@ -0,0 +1,55 @@
+package sim
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+)
+
+// TraceEntry is a single recorded sample of a headless simulation run.
+type TraceEntry struct {
+	T               float64 `json:"t"`
+	X               float64 `json:"x"`
+	Y               float64 `json:"y"`
+	Heading         float64 `json:"heading"`
+	VX              float64 `json:"vx"`
+	VY              float64 `json:"vy"`
+	Steering        float64 `json:"steering"`
+	Throttle        float64 `json:"throttle"`
+	Brake           float64 `json:"brake"`
+	CrossTrackError float64 `json:"cross_track_error"`
+	PathProgress    float64 `json:"path_progress"`
+}
+
+// TraceWriter appends TraceEntry values to a JSON-lines trace file.
+type TraceWriter struct {
+	enc *json.Encoder
+}
+
+// NewTraceWriter wraps w as a JSON-lines trace writer.
+func NewTraceWriter(w io.Writer) *TraceWriter {
+	return &TraceWriter{enc: json.NewEncoder(w)}
+}
+
+// Write appends a single trace entry as a line of JSON.
+func (w *TraceWriter) Write(entry TraceEntry) error {
+	return w.enc.Encode(entry)
+}
+
+// ReadTrace reads a JSON-lines trace file back into a slice of entries, in recorded order.
+func ReadTrace(r io.Reader) ([]TraceEntry, error) {
+	var entries []TraceEntry
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var entry TraceEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}